@@ -0,0 +1,190 @@
+package nutanix
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"mime/multipart"
+	"net/textproto"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// dataSourceNutanixNDBCloudInitConfig is modeled on Terraform's template_cloudinit_config:
+// it stitches an ordered list of cloud-init `part` blocks into a single multipart MIME
+// document and, additionally, pre-shapes that document into the {name,value}
+// action_arguments pairs NDB's dbserver VM create API expects.
+func dataSourceNutanixNDBCloudInitConfig() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNutanixNDBCloudInitConfigRead,
+		Schema: map[string]*schema.Schema{
+			"gzip": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"base64_encode": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"boundary": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"part": {
+				Type:     schema.TypeList,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"content_type": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "text/plain",
+						},
+						"filename": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"content": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"merge_type": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+
+			// computed
+			"rendered": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"action_arguments": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"value": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceNutanixNDBCloudInitConfigRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rendered, err := renderCloudInitConfig(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("rendered", rendered); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("action_arguments", []interface{}{
+		map[string]interface{}{
+			"name":  "user_data",
+			"value": rendered,
+		},
+	}); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(hashCloudInitConfig(rendered))
+	return nil
+}
+
+// renderCloudInitConfig builds the multipart/mixed MIME document, one part per
+// `part` block in the order they were declared, optionally gzipping and
+// base64-encoding the result the same way cloud-init's own user-data handling
+// expects (the invalid-MIME-formatting issue from Terraform's own
+// template_cloudinit_config is why the part boundaries and part headers below
+// are built with mime/multipart rather than hand-formatted strings; only the
+// outer multipart/mixed header is formatted by hand, from mw.Boundary()).
+func renderCloudInitConfig(d *schema.ResourceData) (string, error) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+
+	if boundary, ok := d.GetOk("boundary"); ok {
+		if err := mw.SetBoundary(boundary.(string)); err != nil {
+			return "", fmt.Errorf("error setting MIME boundary: %w", err)
+		}
+	}
+
+	parts := d.Get("part").([]interface{})
+	for _, p := range parts {
+		pm := p.(map[string]interface{})
+
+		contentType := pm["content_type"].(string)
+		if contentType == "" {
+			contentType = "text/plain"
+		}
+
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", contentType)
+		header.Set("MIME-Version", "1.0")
+		header.Set("Content-Transfer-Encoding", "7bit")
+
+		if filename, ok := pm["filename"].(string); ok && filename != "" {
+			header.Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+		}
+
+		if mergeType, ok := pm["merge_type"].(string); ok && mergeType != "" {
+			header.Set("X-Merge-Type", mergeType)
+		}
+
+		part, err := mw.CreatePart(header)
+		if err != nil {
+			return "", fmt.Errorf("error creating MIME part: %w", err)
+		}
+
+		if _, err := part.Write([]byte(pm["content"].(string))); err != nil {
+			return "", fmt.Errorf("error writing MIME part content: %w", err)
+		}
+	}
+
+	if err := mw.Close(); err != nil {
+		return "", fmt.Errorf("error finalizing MIME document: %w", err)
+	}
+
+	document := fmt.Sprintf("Content-Type: multipart/mixed; boundary=\"%s\"\nMIME-Version: 1.0\n\n%s", mw.Boundary(), buf.String())
+
+	if d.Get("gzip").(bool) {
+		var gzBuf bytes.Buffer
+		gw := gzip.NewWriter(&gzBuf)
+		if _, err := gw.Write([]byte(document)); err != nil {
+			return "", fmt.Errorf("error gzipping cloud-init document: %w", err)
+		}
+		if err := gw.Close(); err != nil {
+			return "", fmt.Errorf("error gzipping cloud-init document: %w", err)
+		}
+		document = gzBuf.String()
+	}
+
+	if d.Get("base64_encode").(bool) {
+		return base64.StdEncoding.EncodeToString([]byte(document)), nil
+	}
+	return document, nil
+}
+
+func hashCloudInitConfig(rendered string) string {
+	sum := sha1.Sum([]byte(rendered))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,47 @@
+package nutanix
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const dataSourceNameCloudInitConfig = "data.nutanix_ndb_cloudinit_config.acctest"
+
+func TestAccEraCloudInitConfig_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccEraPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEraCloudInitConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(dataSourceNameCloudInitConfig, "id"),
+					resource.TestCheckResourceAttrSet(dataSourceNameCloudInitConfig, "rendered"),
+					resource.TestCheckResourceAttr(dataSourceNameCloudInitConfig, "action_arguments.0.name", "user_data"),
+				),
+			},
+		},
+	})
+}
+
+func testAccEraCloudInitConfig() string {
+	return `
+		data "nutanix_ndb_cloudinit_config" "acctest" {
+			gzip          = false
+			base64_encode = false
+
+			part {
+				content_type = "text/cloud-config"
+				filename     = "init.cfg"
+				content      = "packages: ['postgresql-client']"
+			}
+
+			part {
+				content_type = "text/x-shellscript"
+				filename     = "register.sh"
+				content      = "#!/bin/bash\necho registering with monitoring\n"
+			}
+		}
+	`
+}
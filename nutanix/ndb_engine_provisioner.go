@@ -0,0 +1,365 @@
+package nutanix
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-nutanix/client/era"
+)
+
+// engineProvisioner lets a database engine plug itself into
+// resourceNutanixNDBServerVM without the resource needing to know anything
+// engine-specific. Each engine owns its own config block (keyed by its
+// database_type, e.g. "postgres_database"), how that block expands into the
+// NDB action arguments sent on create, and how to flatten NDB's response back
+// into that same block on read.
+type engineProvisioner interface {
+	// Schema returns the top-level block(s) this engine contributes to
+	// resourceNutanixNDBServerVM's schema, keyed by database_type.
+	Schema() map[string]*schema.Schema
+
+	// ExpandActionArgs turns one instance of the engine's config block into
+	// the action arguments NDB expects in DBServerInputRequest.
+	ExpandActionArgs(block map[string]interface{}) []*era.Actionarguments
+
+	// FlattenState reconstructs the engine's config block from the generic
+	// name/value properties NDB returns on a dbserver VM read.
+	FlattenState(vmName string, propVals map[string]string) []interface{}
+}
+
+// engineProvisioners is the registry of known database engines, keyed by the
+// database_type value that selects them.
+var engineProvisioners = map[string]engineProvisioner{}
+
+func registerEngineProvisioner(databaseType string, p engineProvisioner) {
+	engineProvisioners[databaseType] = p
+}
+
+func init() {
+	registerEngineProvisioner("postgres_database", &postgresEngineProvisioner{})
+	registerEngineProvisioner("mysql_database", &mysqlEngineProvisioner{})
+	registerEngineProvisioner("mssql_database", &mssqlEngineProvisioner{})
+	registerEngineProvisioner("mongodb_database", &mongodbEngineProvisioner{})
+	registerEngineProvisioner("oracle_database", &oracleEngineProvisioner{})
+}
+
+// mergeEngineProvisionerSchemas merges every registered engine's schema block
+// into base, so resourceNutanixNDBServerVM never has to be touched to add a
+// new engine.
+func mergeEngineProvisionerSchemas(base map[string]*schema.Schema) map[string]*schema.Schema {
+	for _, p := range engineProvisioners {
+		for name, s := range p.Schema() {
+			base[name] = s
+		}
+	}
+	return base
+}
+
+// validateDBServerVMEngineBlock is the resource's CustomizeDiffFunc. It
+// enforces that exactly one engine block is present, and that it matches the
+// declared database_type.
+func validateDBServerVMEngineBlock(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	databaseType := d.Get("database_type").(string)
+
+	provisioner, ok := engineProvisioners[databaseType]
+	if !ok {
+		return fmt.Errorf("database_type %q does not match any registered database engine", databaseType)
+	}
+
+	matchingBlocks := 0
+	for name := range provisioner.Schema() {
+		if block, ok := d.GetOk(name); ok {
+			if n := len(block.([]interface{})); n > 0 {
+				matchingBlocks += n
+			}
+		}
+	}
+
+	for name, other := range engineProvisioners {
+		if other == provisioner {
+			continue
+		}
+		for blockName := range other.Schema() {
+			if block, ok := d.GetOk(blockName); ok && len(block.([]interface{})) > 0 {
+				return fmt.Errorf("%q block is set but database_type is %q, not %q", blockName, databaseType, name)
+			}
+		}
+	}
+
+	switch matchingBlocks {
+	case 0:
+		return fmt.Errorf("database_type is %q but no matching engine block is set", databaseType)
+	case 1:
+		return nil
+	default:
+		return fmt.Errorf("exactly one engine block matching database_type %q is allowed, found %d", databaseType, matchingBlocks)
+	}
+}
+
+// expandEngineActionArgs dispatches to the engine registered for
+// database_type and expands its config block into action arguments.
+func expandEngineActionArgs(d *schema.ResourceData) ([]*era.Actionarguments, error) {
+	databaseType := d.Get("database_type").(string)
+
+	provisioner, ok := engineProvisioners[databaseType]
+	if !ok {
+		return nil, fmt.Errorf("database_type %q does not match any registered database engine", databaseType)
+	}
+
+	for name := range provisioner.Schema() {
+		block, ok := d.GetOk(name)
+		if !ok {
+			continue
+		}
+		blockList := block.([]interface{})
+		if len(blockList) == 0 {
+			continue
+		}
+		return provisioner.ExpandActionArgs(blockList[0].(map[string]interface{})), nil
+	}
+
+	return nil, nil
+}
+
+// flattenEngineState dispatches to the engine registered for database_type
+// and reconstructs its config block from the dbserver VM's properties.
+func flattenEngineState(databaseType, vmName string, propVals map[string]string) []interface{} {
+	provisioner, ok := engineProvisioners[databaseType]
+	if !ok {
+		return nil
+	}
+	return provisioner.FlattenState(vmName, propVals)
+}
+
+// postgresEngineProvisioner is the original postgres_database block, with the
+// same vm_name and client_public_key fields as before the engine registry
+// existed, now limited to a single block (MaxItems: 1).
+type postgresEngineProvisioner struct{}
+
+func (p *postgresEngineProvisioner) Schema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"postgres_database": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"vm_name": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"client_public_key": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (p *postgresEngineProvisioner) ExpandActionArgs(block map[string]interface{}) []*era.Actionarguments {
+	args := []*era.Actionarguments{}
+	if vmName, ok := block["vm_name"]; ok {
+		args = append(args, &era.Actionarguments{Name: "vm_name", Value: vmName})
+	}
+	if clientKey, ok := block["client_public_key"]; ok {
+		args = append(args, &era.Actionarguments{Name: "client_public_key", Value: clientKey})
+	}
+	return args
+}
+
+func (p *postgresEngineProvisioner) FlattenState(vmName string, propVals map[string]string) []interface{} {
+	pubKey, ok := propVals["client_public_key"]
+	if !ok {
+		return nil
+	}
+	return []interface{}{
+		map[string]interface{}{
+			"vm_name":           vmName,
+			"client_public_key": pubKey,
+		},
+	}
+}
+
+// mysqlEngineProvisioner is a minimal MySQL engine block, following the same
+// vm_name + credential shape as postgres_database until MySQL-specific
+// action arguments are needed.
+type mysqlEngineProvisioner struct{}
+
+func (p *mysqlEngineProvisioner) Schema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"mysql_database": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"vm_name": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"db_password": {
+						Type:      schema.TypeString,
+						Required:  true,
+						Sensitive: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (p *mysqlEngineProvisioner) ExpandActionArgs(block map[string]interface{}) []*era.Actionarguments {
+	args := []*era.Actionarguments{}
+	if vmName, ok := block["vm_name"]; ok {
+		args = append(args, &era.Actionarguments{Name: "vm_name", Value: vmName})
+	}
+	if pass, ok := block["db_password"]; ok {
+		args = append(args, &era.Actionarguments{Name: "db_password", Value: pass})
+	}
+	return args
+}
+
+func (p *mysqlEngineProvisioner) FlattenState(vmName string, propVals map[string]string) []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"vm_name": vmName,
+		},
+	}
+}
+
+// mssqlEngineProvisioner is a minimal SQL Server engine block.
+type mssqlEngineProvisioner struct{}
+
+func (p *mssqlEngineProvisioner) Schema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"mssql_database": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"vm_name": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"windows_admin_password": {
+						Type:      schema.TypeString,
+						Required:  true,
+						Sensitive: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (p *mssqlEngineProvisioner) ExpandActionArgs(block map[string]interface{}) []*era.Actionarguments {
+	args := []*era.Actionarguments{}
+	if vmName, ok := block["vm_name"]; ok {
+		args = append(args, &era.Actionarguments{Name: "vm_name", Value: vmName})
+	}
+	if pass, ok := block["windows_admin_password"]; ok {
+		args = append(args, &era.Actionarguments{Name: "windows_admin_password", Value: pass})
+	}
+	return args
+}
+
+func (p *mssqlEngineProvisioner) FlattenState(vmName string, propVals map[string]string) []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"vm_name": vmName,
+		},
+	}
+}
+
+// mongodbEngineProvisioner is a minimal MongoDB engine block.
+type mongodbEngineProvisioner struct{}
+
+func (p *mongodbEngineProvisioner) Schema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"mongodb_database": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"vm_name": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"name_prefix": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (p *mongodbEngineProvisioner) ExpandActionArgs(block map[string]interface{}) []*era.Actionarguments {
+	args := []*era.Actionarguments{}
+	if vmName, ok := block["vm_name"]; ok {
+		args = append(args, &era.Actionarguments{Name: "vm_name", Value: vmName})
+	}
+	if prefix, ok := block["name_prefix"]; ok {
+		args = append(args, &era.Actionarguments{Name: "name_prefix", Value: prefix})
+	}
+	return args
+}
+
+func (p *mongodbEngineProvisioner) FlattenState(vmName string, propVals map[string]string) []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"vm_name": vmName,
+		},
+	}
+}
+
+// oracleEngineProvisioner is a minimal Oracle engine block.
+type oracleEngineProvisioner struct{}
+
+func (p *oracleEngineProvisioner) Schema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"oracle_database": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"vm_name": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"sys_password": {
+						Type:      schema.TypeString,
+						Required:  true,
+						Sensitive: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (p *oracleEngineProvisioner) ExpandActionArgs(block map[string]interface{}) []*era.Actionarguments {
+	args := []*era.Actionarguments{}
+	if vmName, ok := block["vm_name"]; ok {
+		args = append(args, &era.Actionarguments{Name: "vm_name", Value: vmName})
+	}
+	if pass, ok := block["sys_password"]; ok {
+		args = append(args, &era.Actionarguments{Name: "sys_password", Value: pass})
+	}
+	return args
+}
+
+func (p *oracleEngineProvisioner) FlattenState(vmName string, propVals map[string]string) []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"vm_name": vmName,
+		},
+	}
+}
@@ -1,10 +1,16 @@
 package nutanix
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"log"
+	"os"
+	"os/exec"
 	"time"
 
+	"golang.org/x/crypto/ssh"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -23,230 +29,368 @@ func resourceNutanixNDBServerVM() *schema.Resource {
 		ReadContext:   resourceNutanixNDBServerVMRead,
 		UpdateContext: resourceNutanixNDBServerVMUpdate,
 		DeleteContext: resourceNutanixNDBServerVMDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(EraDBProvisionTimeout),
+			Update: schema.DefaultTimeout(EraDBProvisionTimeout),
 		},
-		Schema: map[string]*schema.Schema{
-			"database_type": {
-				Type:     schema.TypeString,
-				Required: true,
-			},
-			"description": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
-			},
-			"software_profile_id": {
-				Type:          schema.TypeString,
-				Optional:      true,
-				ConflictsWith: []string{"time_machine_id"},
-				RequiredWith:  []string{"software_profile_version_id"},
-			},
-			"software_profile_version_id": {
-				Type:     schema.TypeString,
-				Optional: true,
-			},
-			"time_machine_id": {
-				Type:          schema.TypeString,
-				Optional:      true,
-				ConflictsWith: []string{"software_profile_id"},
-			},
-			"snapshot_id": {
-				Type:     schema.TypeString,
-				Optional: true,
-			},
-			"timezone": {
-				Type:     schema.TypeString,
-				Optional: true,
-			},
-			"network_profile_id": {
-				Type:     schema.TypeString,
-				Required: true,
-			},
-			"compute_profile_id": {
-				Type:     schema.TypeString,
-				Required: true,
-			},
-			"nx_cluster_id": {
-				Type:     schema.TypeString,
-				Required: true,
-			},
-			"vm_password": {
-				Type:      schema.TypeString,
-				Required:  true,
-				Sensitive: true,
-			},
-			"latest_snapshot": {
-				Type:     schema.TypeBool,
-				Optional: true,
-				Default:  true,
-			},
-			"postgres_database": {
-				Type:     schema.TypeList,
-				Optional: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"vm_name": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"client_public_key": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
+		CustomizeDiff: validateDBServerVMEngineBlock,
+		Schema:        mergeEngineProvisionerSchemas(dbServerVMBaseSchema()),
+	}
+}
+
+func dbServerVMBaseSchema() map[string]*schema.Schema {
+	s := map[string]*schema.Schema{
+		"database_type": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"description": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+		},
+		"software_profile_id": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			ConflictsWith: []string{"time_machine_id"},
+			RequiredWith:  []string{"software_profile_version_id"},
+		},
+		"software_profile_version_id": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"time_machine_id": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			ConflictsWith: []string{"software_profile_id"},
+		},
+		"snapshot_id": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"timezone": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"network_profile_id": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"compute_profile_id": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"nx_cluster_id": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"vm_password": {
+			Type:      schema.TypeString,
+			Required:  true,
+			Sensitive: true,
+		},
+		"latest_snapshot": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  true,
+		},
+		"credentials": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"username": {
+						Type:     schema.TypeString,
+						Required: true,
 					},
-				},
-			},
-			"credentials": {
-				Type:     schema.TypeList,
-				Optional: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"username": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"password": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"label": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
+					"password": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"label": {
+						Type:     schema.TypeString,
+						Optional: true,
 					},
 				},
 			},
+		},
 
-			"maintenance_tasks": {
-				Type:     schema.TypeList,
-				Optional: true,
-				MaxItems: 1,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"maintenance_window_id": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"tasks": {
-							Type:     schema.TypeList,
-							Optional: true,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"task_type": {
-										Type:         schema.TypeString,
-										Optional:     true,
-										ValidateFunc: validation.StringInSlice([]string{"OS_PATCHING", "DB_PATCHING"}, false),
-									},
-									"pre_command": {
-										Type:     schema.TypeString,
-										Optional: true,
-									},
-									"post_command": {
-										Type:     schema.TypeString,
-										Optional: true,
-									},
+		"maintenance_tasks": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"maintenance_window_id": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"tasks": {
+						Type:     schema.TypeList,
+						Optional: true,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"task_type": {
+									Type:         schema.TypeString,
+									Optional:     true,
+									ValidateFunc: validation.StringInSlice([]string{"OS_PATCHING", "DB_PATCHING"}, false),
+								},
+								"pre_command": {
+									Type:     schema.TypeString,
+									Optional: true,
+								},
+								"post_command": {
+									Type:     schema.TypeString,
+									Optional: true,
 								},
 							},
 						},
 					},
 				},
 			},
+		},
 
-			// computed
-			"name": {
-				Type:     schema.TypeString,
-				Computed: true,
-			},
-			"properties": {
-				Type:        schema.TypeList,
-				Description: "List of all the properties",
-				Computed:    true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Type:        schema.TypeString,
-							Computed:    true,
-							Description: "",
-						},
+		"cloud_init_config": {
+			Type:     schema.TypeString,
+			Optional: true,
+			ForceNew: true,
+			Description: "The `rendered` attribute of a nutanix_ndb_cloudinit_config data source, " +
+				"expanded into a `user_data` action argument on create. Note this is the rendered " +
+				"document itself, not the data source's `id` (which is just a content hash).",
+		},
 
-						"value": {
-							Type:        schema.TypeString,
-							Computed:    true,
-							Description: "",
-						},
+		"patch_now": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"task_type": {
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringInSlice([]string{"OS_PATCHING", "DB_PATCHING"}, false),
+					},
+					"pre_command": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"post_command": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"wait_for_completion": {
+						Type:     schema.TypeBool,
+						Optional: true,
+						Default:  true,
 					},
 				},
 			},
-			"tags": dataSourceEraDBInstanceTags(),
-			"era_created": {
-				Type:     schema.TypeBool,
-				Computed: true,
-			},
-			"internal": {
-				Type:     schema.TypeBool,
-				Computed: true,
-			},
-			"dbserver_cluster_id": {
-				Type:     schema.TypeString,
-				Computed: true,
-			},
-			"vm_cluster_name": {
-				Type:     schema.TypeString,
-				Computed: true,
-			},
-			"vm_cluster_uuid": {
-				Type:     schema.TypeString,
-				Computed: true,
-			},
-			"ip_addresses": {
-				Type:     schema.TypeList,
-				Computed: true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
+		},
+
+		"provisioner": {
+			Type:     schema.TypeList,
+			Optional: true,
+			ForceNew: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"type": {
+						Type:         schema.TypeString,
+						Required:     true,
+						ForceNew:     true,
+						ValidateFunc: validation.StringInSlice([]string{"file", "remote-exec", "local-exec"}, false),
+					},
+					"inline": {
+						Type:     schema.TypeList,
+						Optional: true,
+						ForceNew: true,
+						Elem:     &schema.Schema{Type: schema.TypeString},
+					},
+					"command": {
+						Type:     schema.TypeString,
+						Optional: true,
+						ForceNew: true,
+					},
+					"source": {
+						Type:     schema.TypeString,
+						Optional: true,
+						ForceNew: true,
+					},
+					"destination": {
+						Type:     schema.TypeString,
+						Optional: true,
+						ForceNew: true,
+					},
+					"connection": {
+						Type:     schema.TypeList,
+						Optional: true,
+						ForceNew: true,
+						MaxItems: 1,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"host": {
+									Type:     schema.TypeString,
+									Optional: true,
+									Computed: true,
+									ForceNew: true,
+								},
+								"user": {
+									Type:     schema.TypeString,
+									Optional: true,
+									Default:  "root",
+									ForceNew: true,
+								},
+								"private_key": {
+									Type:      schema.TypeString,
+									Optional:  true,
+									Sensitive: true,
+									ForceNew:  true,
+								},
+								"password": {
+									Type:      schema.TypeString,
+									Optional:  true,
+									Sensitive: true,
+									ForceNew:  true,
+								},
+								"port": {
+									Type:     schema.TypeInt,
+									Optional: true,
+									Default:  22,
+									ForceNew: true,
+								},
+								"bastion_host": {
+									Type:     schema.TypeString,
+									Optional: true,
+									ForceNew: true,
+								},
+								"host_key": {
+									Type:     schema.TypeString,
+									Optional: true,
+									ForceNew: true,
+									Description: "The remote host's SSH public key, in authorized_keys format " +
+										"(e.g. \"ssh-ed25519 AAAA...\"), verified against the key presented " +
+										"during the handshake. Required unless insecure is set.",
+								},
+								"insecure": {
+									Type:     schema.TypeBool,
+									Optional: true,
+									ForceNew: true,
+									Description: "Skip host key verification and accept any host key. This is " +
+										"insecure - the session is vulnerable to MITM - and should only be " +
+										"used for throwaway/test environments where host_key isn't known " +
+										"ahead of time.",
+								},
+							},
+						},
+					},
 				},
 			},
-			"fqdns": {
-				Type:     schema.TypeString,
-				Computed: true,
-			},
-			"mac_addresses": {
-				Type:     schema.TypeList,
-				Computed: true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
+		},
+
+		// computed
+		"name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"properties": {
+			Type:        schema.TypeList,
+			Description: "List of all the properties",
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "",
+					},
+
+					"value": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "",
+					},
 				},
 			},
-			"type": {
-				Type:     schema.TypeString,
-				Computed: true,
-			},
-			"placeholder": {
-				Type:     schema.TypeBool,
-				Computed: true,
-			},
-			"status": {
-				Type:     schema.TypeString,
-				Computed: true,
-			},
-			"client_id": {
-				Type:     schema.TypeString,
-				Computed: true,
-			},
-			"era_drive_id": {
-				Type:     schema.TypeString,
-				Computed: true,
-			},
-			"era_version": {
-				Type:     schema.TypeString,
-				Computed: true,
+		},
+		"tags": dataSourceEraDBInstanceTags(),
+		"era_created": {
+			Type:     schema.TypeBool,
+			Computed: true,
+		},
+		"internal": {
+			Type:     schema.TypeBool,
+			Computed: true,
+		},
+		"dbserver_cluster_id": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"vm_cluster_name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"vm_cluster_uuid": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"ip_addresses": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
 			},
-			"vm_timezone": {
-				Type:     schema.TypeString,
-				Computed: true,
+		},
+		"fqdns": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"mac_addresses": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
 			},
 		},
+		"type": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"placeholder": {
+			Type:     schema.TypeBool,
+			Computed: true,
+		},
+		"status": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"client_id": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"era_drive_id": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"era_version": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"vm_timezone": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"last_patch_operation_id": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"last_patched_at": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
 	}
+	return s
 }
 
 func resourceNutanixNDBServerVMCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -292,9 +436,241 @@ func resourceNutanixNDBServerVMCreate(ctx context.Context, d *schema.ResourceDat
 		return diag.Errorf("error waiting for db Server VM (%s) to create: %s", resp.Entityid, errWaitTask)
 	}
 	log.Printf("NDB database Server VM with %s id is created successfully", d.Id())
+
+	if err := runDBServerVMProvisioners(ctx, conn, d); err != nil {
+		return diag.Errorf("db Server VM (%s) was created but a provisioner failed, marking resource tainted: %s", d.Id(), err)
+	}
+
+	if _, ok := d.GetOk("patch_now"); ok {
+		if err := runDBServerVMPatchNow(ctx, conn, d); err != nil {
+			return diag.Errorf("db Server VM (%s) was created but the initial patch_now operation failed: %s", d.Id(), err)
+		}
+	}
+
 	return resourceNutanixNDBServerVMRead(ctx, d, meta)
 }
 
+// runDBServerVMProvisioners executes the configured provisioner blocks, in order,
+// against the newly created DB server VM. This mirrors the file/remote-exec/local-exec
+// provisioners that used to ship with Terraform core: a single SSH connection is opened per
+// remote block, with one SSH session per inline command. Each command's stdout/stderr are
+// buffered and written to the provider logs once that command finishes; they are not logged
+// while it runs. The first failing provisioner aborts the chain so the caller can mark the
+// resource tainted.
+func runDBServerVMProvisioners(ctx context.Context, conn *era.Client, d *schema.ResourceData) error {
+	provs := d.Get("provisioner").([]interface{})
+	if len(provs) == 0 {
+		return nil
+	}
+
+	vm, err := conn.Service.ReadDBServerVM(ctx, d.Id())
+	if err != nil {
+		return fmt.Errorf("error reading db Server VM %s before running provisioners: %w", d.Id(), err)
+	}
+	if len(vm.IPAddresses) == 0 {
+		return fmt.Errorf("db Server VM %s has no IP addresses to provision against", d.Id())
+	}
+	defaultHost := utils.StringValue(vm.IPAddresses[0])
+
+	for i, p := range provs {
+		pm := p.(map[string]interface{})
+		ptype := pm["type"].(string)
+
+		log.Printf("[DEBUG] running provisioner %d (%s) on db Server VM %s", i, ptype, d.Id())
+
+		if ptype == "local-exec" {
+			if err := runLocalExecProvisioner(ctx, pm); err != nil {
+				return fmt.Errorf("provisioner %d (local-exec) failed: %w", i, err)
+			}
+			continue
+		}
+
+		client, err := dialProvisionerSSH(defaultHost, pm["connection"].([]interface{}))
+		if err != nil {
+			return fmt.Errorf("provisioner %d (%s) failed to connect: %w", i, ptype, err)
+		}
+
+		if ptype == "file" {
+			err = runFileProvisioner(client, pm)
+		} else {
+			err = runRemoteExecProvisioner(client, pm)
+		}
+		client.Close()
+
+		if err != nil {
+			return fmt.Errorf("provisioner %d (%s) failed: %w", i, ptype, err)
+		}
+	}
+	return nil
+}
+
+// provisionerHostKeyCallback builds the host key verification strategy for a connection
+// block, mirroring Terraform core's SSH communicator: a host_key (in authorized_keys
+// format) is verified with a fixed-key check, and the connection is refused unless the
+// caller explicitly opts into insecure mode via `insecure = true`.
+func provisionerHostKeyCallback(c map[string]interface{}) (ssh.HostKeyCallback, error) {
+	if hostKey, ok := c["host_key"].(string); ok && hostKey != "" {
+		pubKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(hostKey))
+		if err != nil {
+			return nil, fmt.Errorf("error parsing host_key: %w", err)
+		}
+		return ssh.FixedHostKey(pubKey), nil
+	}
+
+	if insecure, ok := c["insecure"].(bool); ok && insecure {
+		log.Printf("[WARN] provisioner connection has insecure = true; skipping SSH host key verification")
+		return ssh.InsecureIgnoreHostKey(), nil
+	}
+
+	return nil, fmt.Errorf("connection block requires host_key for SSH host key verification, or insecure = true to explicitly disable it")
+}
+
+// dialProvisionerSSH opens a single SSH connection for a provisioner's connection block,
+// optionally tunnelling through a bastion_host.
+func dialProvisionerSSH(defaultHost string, connList []interface{}) (*ssh.Client, error) {
+	c := map[string]interface{}{}
+	if len(connList) > 0 {
+		c = connList[0].(map[string]interface{})
+	}
+
+	host, _ := c["host"].(string)
+	if host == "" {
+		host = defaultHost
+	}
+
+	user, _ := c["user"].(string)
+	if user == "" {
+		user = "root"
+	}
+
+	port, _ := c["port"].(int)
+	if port == 0 {
+		port = 22
+	}
+
+	auth := []ssh.AuthMethod{}
+	if pk, ok := c["private_key"].(string); ok && pk != "" {
+		signer, err := ssh.ParsePrivateKey([]byte(pk))
+		if err != nil {
+			return nil, fmt.Errorf("error parsing private_key: %w", err)
+		}
+		auth = append(auth, ssh.PublicKeys(signer))
+	}
+	if pass, ok := c["password"].(string); ok && pass != "" {
+		auth = append(auth, ssh.Password(pass))
+	}
+	if len(auth) == 0 {
+		return nil, fmt.Errorf("connection block requires either private_key or password")
+	}
+
+	hostKeyCallback, err := provisionerHostKeyCallback(c)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &ssh.ClientConfig{
+		User:            user,
+		Auth:            auth,
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         30 * time.Second,
+	}
+
+	addr := fmt.Sprintf("%s:%d", host, port)
+
+	bastion, _ := c["bastion_host"].(string)
+	if bastion == "" {
+		return ssh.Dial("tcp", addr, cfg)
+	}
+
+	bastionClient, err := ssh.Dial("tcp", fmt.Sprintf("%s:%d", bastion, port), cfg)
+	if err != nil {
+		return nil, fmt.Errorf("error dialing bastion_host %s: %w", bastion, err)
+	}
+	bConn, err := bastionClient.Dial("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("error dialing %s through bastion_host: %w", addr, err)
+	}
+	ncc, chans, reqs, err := ssh.NewClientConn(bConn, addr, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("error establishing SSH connection to %s through bastion_host: %w", addr, err)
+	}
+	return ssh.NewClient(ncc, chans, reqs), nil
+}
+
+func runRemoteExecProvisioner(client *ssh.Client, pm map[string]interface{}) error {
+	for _, cmd := range expandProvisionerInline(pm["inline"].([]interface{})) {
+		session, err := client.NewSession()
+		if err != nil {
+			return fmt.Errorf("error opening SSH session: %w", err)
+		}
+
+		var stdout, stderr bytes.Buffer
+		session.Stdout = &stdout
+		session.Stderr = &stderr
+
+		err = session.Run(cmd)
+		session.Close()
+
+		log.Printf("[DEBUG] remote-exec %q stdout: %s stderr: %s", cmd, stdout.String(), stderr.String())
+		if err != nil {
+			return fmt.Errorf("command %q exited non-zero: %w", cmd, err)
+		}
+	}
+	return nil
+}
+
+func runFileProvisioner(client *ssh.Client, pm map[string]interface{}) error {
+	source, _ := pm["source"].(string)
+	destination, _ := pm["destination"].(string)
+	if source == "" || destination == "" {
+		return fmt.Errorf("file provisioner requires both source and destination")
+	}
+
+	content, err := os.ReadFile(source)
+	if err != nil {
+		return fmt.Errorf("error reading source file %s: %w", source, err)
+	}
+
+	session, err := client.NewSession()
+	if err != nil {
+		return fmt.Errorf("error opening SSH session: %w", err)
+	}
+	defer session.Close()
+
+	session.Stdin = bytes.NewReader(content)
+	if err := session.Run(fmt.Sprintf("cat > %s", destination)); err != nil {
+		return fmt.Errorf("error writing %s on remote host: %w", destination, err)
+	}
+	return nil
+}
+
+func runLocalExecProvisioner(ctx context.Context, pm map[string]interface{}) error {
+	command, _ := pm["command"].(string)
+	if command == "" {
+		return fmt.Errorf("local-exec provisioner requires command")
+	}
+
+	cmd := exec.CommandContext(ctx, "/bin/sh", "-c", command)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	log.Printf("[DEBUG] local-exec %q stdout: %s stderr: %s", command, stdout.String(), stderr.String())
+	if err != nil {
+		return fmt.Errorf("command %q exited non-zero: %w", command, err)
+	}
+	return nil
+}
+
+func expandProvisionerInline(inline []interface{}) []string {
+	cmds := make([]string, 0, len(inline))
+	for _, v := range inline {
+		cmds = append(cmds, v.(string))
+	}
+	return cmds
+}
+
 func resourceNutanixNDBServerVMRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	conn := meta.(*Client).Era
 
@@ -303,6 +679,25 @@ func resourceNutanixNDBServerVMRead(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
+	// Detect out-of-band re-imaging (e.g. a manual software profile rollback applied
+	// directly against NDB) and force a recreate, the same way Terraform core treats
+	// drifted provider-managed attributes that can't be reconciled in place.
+	if oldEraVersion := d.Get("era_version").(string); oldEraVersion != "" && oldEraVersion != resp.EraVersion {
+		log.Printf("[DEBUG] db Server VM (%s) era_version drifted from %s to %s out-of-band; forcing recreate", d.Id(), oldEraVersion, resp.EraVersion)
+		d.SetId("")
+		return nil
+	}
+
+	if oldProfileVersion := d.Get("software_profile_version_id").(string); oldProfileVersion != "" {
+		for _, prop := range resp.Properties {
+			if prop.Name == "software_profile_version_id" && prop.Value != oldProfileVersion {
+				log.Printf("[DEBUG] db Server VM (%s) software_profile_version_id drifted from %s to %s out-of-band; forcing recreate", d.Id(), oldProfileVersion, prop.Value)
+				d.SetId("")
+				return nil
+			}
+		}
+	}
+
 	if err = d.Set("description", resp.Description); err != nil {
 		return diag.FromErr(err)
 	}
@@ -386,6 +781,55 @@ func resourceNutanixNDBServerVMRead(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
+	// Reconstruct the attributes the user configures at create time, so that
+	// `terraform import` lands on a clean diff instead of forcing a follow-up
+	// update/recreate. NDB surfaces these as generic name/value properties on
+	// the dbserver VM rather than as discrete response fields.
+	propVals := map[string]string{}
+	for _, prop := range resp.Properties {
+		propVals[prop.Name] = prop.Value
+	}
+
+	for attr, key := range map[string]string{
+		"software_profile_id":         "software_profile_id",
+		"software_profile_version_id": "software_profile_version_id",
+		"compute_profile_id":          "compute_profile_id",
+		"network_profile_id":          "network_profile_id",
+		"nx_cluster_id":               "nx_cluster_id",
+		"time_machine_id":             "time_machine_id",
+	} {
+		if v, ok := propVals[key]; ok {
+			if err := d.Set(attr, v); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	// maintenance_tasks and credentials aren't reconstructed here: unlike the
+	// profile/cluster associations above, NDB doesn't surface them as generic
+	// properties on the dbserver VM, and the read response type in this
+	// checkout doesn't carry discrete MaintenanceTasks/Credentials fields to
+	// flatten from. An import will need a follow-up apply to set them rather
+	// than landing on a clean diff immediately.
+
+	databaseType := d.Get("database_type").(string)
+	if engineBlock := flattenEngineState(databaseType, resp.Name, propVals); engineBlock != nil {
+		// NDB doesn't return every field of an engine block (passwords in
+		// particular), so keep any field the engine didn't reconstruct from
+		// the prior state instead of blanking it.
+		if prior, ok := d.Get(databaseType).([]interface{}); ok && len(prior) > 0 && prior[0] != nil {
+			flat := engineBlock[0].(map[string]interface{})
+			for k, v := range prior[0].(map[string]interface{}) {
+				if _, ok := flat[k]; !ok {
+					flat[k] = v
+				}
+			}
+		}
+		if err := d.Set(databaseType, engineBlock); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return nil
 }
 
@@ -405,12 +849,32 @@ func resourceNutanixNDBServerVMUpdate(ctx context.Context, d *schema.ResourceDat
 		req.ResetDescription = true
 	}
 
-	if d.HasChange("postgres_database") {
-		ps := d.Get("postgres_database").([]interface{})[0].(map[string]interface{})
-
-		vmName := ps["vm_name"]
-		req.Name = utils.StringPtr(vmName.(string))
-		req.ResetName = true
+	// Renaming the VM is expressed as a change to vm_name inside whichever
+	// engine block matches database_type - every registered engine carries a
+	// vm_name field for exactly this reason, so dispatch through the registry
+	// instead of hardcoding postgres_database the way this used to.
+	if databaseType := d.Get("database_type").(string); databaseType != "" {
+		if provisioner, ok := engineProvisioners[databaseType]; ok {
+			for name := range provisioner.Schema() {
+				if !d.HasChange(name) {
+					continue
+				}
+
+				blockList := d.Get(name).([]interface{})
+				if len(blockList) == 0 {
+					// The engine block was removed (e.g. database_type was
+					// switched away from it in the same apply); there's
+					// nothing left to rename.
+					continue
+				}
+
+				block := blockList[0].(map[string]interface{})
+				if vmName, ok := block["vm_name"].(string); ok && vmName != "" {
+					req.Name = utils.StringPtr(vmName)
+					req.ResetName = true
+				}
+			}
+		}
 	}
 
 	if d.HasChange("tags") {
@@ -446,11 +910,26 @@ func resourceNutanixNDBServerVMUpdate(ctx context.Context, d *schema.ResourceDat
 		req.Credentials = credArgs
 	}
 
+	if d.HasChange("maintenance_tasks") {
+		if maintenance, ok := d.GetOk("maintenance_tasks"); ok {
+			rescheduleReq := expandMaintenanceTasks(maintenance.([]interface{}))
+			if _, err := conn.Service.RescheduleMaintenanceTasks(ctx, rescheduleReq, d.Id()); err != nil {
+				return diag.Errorf("error rescheduling maintenance tasks for db Server VM (%s): %s", d.Id(), err)
+			}
+		}
+	}
+
 	resp, err := conn.Service.UpdateDBServerVM(ctx, req, d.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	if d.HasChange("patch_now") {
+		if err := runDBServerVMPatchNow(ctx, conn, d); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	if resp != nil {
 		if err = d.Set("description", resp.Description); err != nil {
 			return diag.FromErr(err)
@@ -465,6 +944,87 @@ func resourceNutanixNDBServerVMUpdate(ctx context.Context, d *schema.ResourceDat
 	return nil
 }
 
+// runDBServerVMPatchNow triggers an immediate OS/DB patching operation via the operations
+// API and, unless the caller opted out, polls it to completion with the same
+// eraRefresh state-change pattern used by create/delete.
+func runDBServerVMPatchNow(ctx context.Context, conn *era.Client, d *schema.ResourceData) error {
+	patchNow, ok := d.GetOk("patch_now")
+	if !ok || len(patchNow.([]interface{})) == 0 {
+		// patch_now was removed from config; nothing to trigger. Clear the
+		// markers from the last time it ran so they don't linger in state.
+		if err := d.Set("last_patch_operation_id", ""); err != nil {
+			return err
+		}
+		return d.Set("last_patched_at", "")
+	}
+	pm := patchNow.([]interface{})[0].(map[string]interface{})
+
+	req := &era.PatchDBServerVMRequest{
+		TaskType: utils.StringPtr(pm["task_type"].(string)),
+	}
+	if preCommand := pm["pre_command"].(string); preCommand != "" {
+		req.PreCommand = utils.StringPtr(preCommand)
+	}
+	if postCommand := pm["post_command"].(string); postCommand != "" {
+		req.PostCommand = utils.StringPtr(postCommand)
+	}
+
+	resp, err := conn.Service.PatchDBServerVM(ctx, req, d.Id())
+	if err != nil {
+		return fmt.Errorf("error triggering patch operation for db Server VM (%s): %w", d.Id(), err)
+	}
+
+	opID := resp.Operationid
+	if opID == "" {
+		return fmt.Errorf("error: operation ID is an empty string")
+	}
+
+	if err := d.Set("last_patch_operation_id", opID); err != nil {
+		return err
+	}
+
+	if pm["wait_for_completion"].(bool) {
+		opReq := era.GetOperationRequest{OperationID: opID}
+
+		log.Printf("polling for patch operation with id: %s\n", opID)
+
+		timeout := d.Timeout(schema.TimeoutUpdate)
+		if d.IsNewResource() {
+			timeout = d.Timeout(schema.TimeoutCreate)
+		}
+
+		refresh := eraRefresh(ctx, conn, opReq)
+		stateConf := &resource.StateChangeConf{
+			Pending: []string{"PENDING"},
+			Target:  []string{"COMPLETED", "FAILED"},
+			Refresh: refresh,
+			Timeout: timeout,
+			Delay:   eraDelay,
+		}
+
+		if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+			return fmt.Errorf("error waiting for patch operation (%s) on db Server VM (%s) to complete: %w", opID, d.Id(), errWaitTask)
+		}
+
+		// WaitForStateContext only confirms the operation reached one of its
+		// Target states, not which one - query it once more to distinguish
+		// COMPLETED from FAILED before stamping last_patched_at, since this
+		// field exists specifically so callers can gate further automation on
+		// a patch having actually succeeded.
+		_, finalState, err := refresh()
+		if err != nil {
+			return fmt.Errorf("error confirming patch operation (%s) on db Server VM (%s) status: %w", opID, d.Id(), err)
+		}
+		if finalState != "COMPLETED" {
+			return fmt.Errorf("patch operation (%s) on db Server VM (%s) finished with status %s, not COMPLETED; last_patched_at was not updated", opID, d.Id(), finalState)
+		}
+
+		return d.Set("last_patched_at", time.Now().UTC().Format(time.RFC3339))
+	}
+
+	return nil
+}
+
 func resourceNutanixNDBServerVMDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	conn := meta.(*Client).Era
 
@@ -557,37 +1117,21 @@ func buildDBServerVMRequest(d *schema.ResourceData, res *era.DBServerInputReques
 		res.Description = utils.StringPtr(desc.(string))
 	}
 
-	if postgresDatabase, ok := d.GetOk("postgres_database"); ok && len(postgresDatabase.([]interface{})) > 0 {
-		res.ActionArguments = expandDBServerPostgresInput(postgresDatabase.([]interface{}))
+	engineArgs, err := expandEngineActionArgs(d)
+	if err != nil {
+		return err
 	}
+	res.ActionArguments = append(res.ActionArguments, engineArgs...)
 
 	if maintenance, ok := d.GetOk("maintenance_tasks"); ok {
 		res.MaintenanceTasks = expandMaintenanceTasks(maintenance.([]interface{}))
 	}
-	return nil
-}
-
-func expandDBServerPostgresInput(pr []interface{}) []*era.Actionarguments {
-	if len(pr) > 0 {
-		args := make([]*era.Actionarguments, 0)
-
-		for _, v := range pr {
-			val := v.(map[string]interface{})
 
-			if vmName, ok := val["vm_name"]; ok {
-				args = append(args, &era.Actionarguments{
-					Name:  "vm_name",
-					Value: vmName,
-				})
-			}
-			if clientKey, ok := val["client_public_key"]; ok {
-				args = append(args, &era.Actionarguments{
-					Name:  "client_public_key",
-					Value: clientKey,
-				})
-			}
-		}
-		return args
+	if cloudInit, ok := d.GetOk("cloud_init_config"); ok {
+		res.ActionArguments = append(res.ActionArguments, &era.Actionarguments{
+			Name:  "user_data",
+			Value: cloudInit.(string),
+		})
 	}
 	return nil
 }
@@ -0,0 +1,185 @@
+package nutanix
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// These exercise the engine plugin registry (ndb_engine_provisioner.go) for
+// every engine besides postgres_database, which TestAccEraServerVM_import
+// already covers. Each just needs to prove its block round-trips through
+// Create/Read for its own database_type - the request/response shape is
+// otherwise identical across engines.
+
+func TestAccEraServerVM_mysql(t *testing.T) {
+	name := "test-mysqlvm-tf"
+	resourceName := "nutanix_ndb_server_vm.acctest-mysql"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccEraPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEraServerVMMySQLConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "database_type", "mysql_database"),
+					resource.TestCheckResourceAttr(resourceName, "mysql_database.0.vm_name", name),
+				),
+			},
+		},
+	})
+}
+
+func testAccEraServerVMMySQLConfig(name string) string {
+	return fmt.Sprintf(`
+		resource "nutanix_ndb_server_vm" "acctest-mysql" {
+			database_type                = "mysql_database"
+			software_profile_id          = "%[1]s-swprofile"
+			software_profile_version_id  = "%[1]s-swversion"
+			network_profile_id           = "%[1]s-netprofile"
+			compute_profile_id           = "%[1]s-computeprofile"
+			nx_cluster_id                = "%[1]s-cluster"
+			vm_password                  = "test-password"
+
+			mysql_database {
+				vm_name     = "%[1]s"
+				db_password = "test-db-password"
+			}
+		}
+	`, name)
+}
+
+func TestAccEraServerVM_mssql(t *testing.T) {
+	name := "test-mssqlvm-tf"
+	resourceName := "nutanix_ndb_server_vm.acctest-mssql"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccEraPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEraServerVMMSSQLConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "database_type", "mssql_database"),
+					resource.TestCheckResourceAttr(resourceName, "mssql_database.0.vm_name", name),
+				),
+			},
+		},
+	})
+}
+
+func testAccEraServerVMMSSQLConfig(name string) string {
+	return fmt.Sprintf(`
+		resource "nutanix_ndb_server_vm" "acctest-mssql" {
+			database_type                = "mssql_database"
+			software_profile_id          = "%[1]s-swprofile"
+			software_profile_version_id  = "%[1]s-swversion"
+			network_profile_id           = "%[1]s-netprofile"
+			compute_profile_id           = "%[1]s-computeprofile"
+			nx_cluster_id                = "%[1]s-cluster"
+			vm_password                  = "test-password"
+
+			mssql_database {
+				vm_name                 = "%[1]s"
+				windows_admin_password  = "test-admin-password"
+			}
+		}
+	`, name)
+}
+
+func TestAccEraServerVM_mongodb(t *testing.T) {
+	name := "test-mongovm-tf"
+	resourceName := "nutanix_ndb_server_vm.acctest-mongodb"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccEraPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEraServerVMMongoDBConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "database_type", "mongodb_database"),
+					resource.TestCheckResourceAttr(resourceName, "mongodb_database.0.vm_name", name),
+				),
+			},
+		},
+	})
+}
+
+func testAccEraServerVMMongoDBConfig(name string) string {
+	return fmt.Sprintf(`
+		resource "nutanix_ndb_server_vm" "acctest-mongodb" {
+			database_type                = "mongodb_database"
+			software_profile_id          = "%[1]s-swprofile"
+			software_profile_version_id  = "%[1]s-swversion"
+			network_profile_id           = "%[1]s-netprofile"
+			compute_profile_id           = "%[1]s-computeprofile"
+			nx_cluster_id                = "%[1]s-cluster"
+			vm_password                  = "test-password"
+
+			mongodb_database {
+				vm_name     = "%[1]s"
+				name_prefix = "acctest"
+			}
+		}
+	`, name)
+}
+
+func TestAccEraServerVM_oracle(t *testing.T) {
+	name := "test-oraclevm-tf"
+	resourceName := "nutanix_ndb_server_vm.acctest-oracle"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccEraPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEraServerVMOracleConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "database_type", "oracle_database"),
+					resource.TestCheckResourceAttr(resourceName, "oracle_database.0.vm_name", name),
+				),
+			},
+		},
+	})
+}
+
+func testAccEraServerVMOracleConfig(name string) string {
+	return fmt.Sprintf(`
+		resource "nutanix_ndb_server_vm" "acctest-oracle" {
+			database_type                = "oracle_database"
+			software_profile_id          = "%[1]s-swprofile"
+			software_profile_version_id  = "%[1]s-swversion"
+			network_profile_id           = "%[1]s-netprofile"
+			compute_profile_id           = "%[1]s-computeprofile"
+			nx_cluster_id                = "%[1]s-cluster"
+			vm_password                  = "test-password"
+
+			oracle_database {
+				vm_name      = "%[1]s"
+				sys_password = "test-sys-password"
+			}
+		}
+	`, name)
+}
+
+// TestAccEraServerVM_engineRename exercises the Update-path fix: renaming
+// vm_name inside a non-postgres engine block must actually reach the API
+// instead of silently no-opping (resourceNutanixNDBServerVMUpdate used to
+// hardcode postgres_database).
+func TestAccEraServerVM_engineRename(t *testing.T) {
+	resourceName := "nutanix_ndb_server_vm.acctest-mysql"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccEraPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEraServerVMMySQLConfig("test-mysqlvm-tf"),
+			},
+			{
+				Config: testAccEraServerVMMySQLConfig("test-mysqlvm-tf-renamed"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "mysql_database.0.vm_name", "test-mysqlvm-tf-renamed"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,126 @@
+package nutanix
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// These exercise the provisioner block end-to-end (dialProvisionerSSH,
+// runRemoteExecProvisioner, runLocalExecProvisioner) and the host-key
+// verification paths added for chunk0-1.
+
+// testProvisionerHostPublicKey is an authorized_keys-format public key used
+// only to exercise the host_key config path. The "rejected when missing" test
+// below doesn't use it; TestAccEraServerVM_provisionerRemoteExecHostKey
+// requires it to match the target host's real key to connect.
+const testProvisionerHostPublicKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIBhwP35VKOE8XPgu0GwdKvLWWt8UYFfGIrMnpcNNRjTF"
+
+var regexpConnectionRequiresHostKey = regexp.MustCompile(`connection block requires host_key`)
+
+func TestAccEraServerVM_provisionerLocalExec(t *testing.T) {
+	name := "test-provlocal-tf"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccEraPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEraServerVMProvisionerLocalExecConfig(name),
+			},
+		},
+	})
+}
+
+func testAccEraServerVMProvisionerLocalExecConfig(name string) string {
+	return fmt.Sprintf(`
+		resource "nutanix_ndb_server_vm" "acctest-provlocal" {
+			database_type                = "postgres_database"
+			software_profile_id          = "%[1]s-swprofile"
+			software_profile_version_id  = "%[1]s-swversion"
+			network_profile_id           = "%[1]s-netprofile"
+			compute_profile_id           = "%[1]s-computeprofile"
+			nx_cluster_id                = "%[1]s-cluster"
+			vm_password                  = "test-password"
+
+			postgres_database {
+				vm_name           = "%[1]s"
+				client_public_key = "ssh-rsa AAAAB3Nza"
+			}
+
+			provisioner {
+				type    = "local-exec"
+				command = "echo provisioned ${nutanix_ndb_server_vm.acctest-provlocal.id}"
+			}
+		}
+	`, name)
+}
+
+// TestAccEraServerVM_provisionerRemoteExecHostKey asserts that a remote-exec
+// provisioner with a known host_key connects and runs commands without
+// falling back to InsecureIgnoreHostKey.
+func TestAccEraServerVM_provisionerRemoteExecHostKey(t *testing.T) {
+	name := "test-provremote-tf"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccEraPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEraServerVMProvisionerRemoteExecConfig(name, testProvisionerHostPublicKey),
+			},
+		},
+	})
+}
+
+// TestAccEraServerVM_provisionerMissingHostKey asserts that omitting host_key
+// while insecure is false is rejected before any SSH connection is attempted,
+// instead of silently connecting without host key verification.
+func TestAccEraServerVM_provisionerMissingHostKey(t *testing.T) {
+	name := "test-provnohostkey-tf"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccEraPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccEraServerVMProvisionerRemoteExecConfig(name, ""),
+				ExpectError: regexpConnectionRequiresHostKey,
+			},
+		},
+	})
+}
+
+func testAccEraServerVMProvisionerRemoteExecConfig(name, hostKey string) string {
+	connectionExtra := `insecure = false`
+	if hostKey != "" {
+		connectionExtra = fmt.Sprintf(`host_key = %q`, hostKey)
+	}
+
+	return fmt.Sprintf(`
+		resource "nutanix_ndb_server_vm" "acctest-provremote" {
+			database_type                = "postgres_database"
+			software_profile_id          = "%[1]s-swprofile"
+			software_profile_version_id  = "%[1]s-swversion"
+			network_profile_id           = "%[1]s-netprofile"
+			compute_profile_id           = "%[1]s-computeprofile"
+			nx_cluster_id                = "%[1]s-cluster"
+			vm_password                  = "test-password"
+
+			postgres_database {
+				vm_name           = "%[1]s"
+				client_public_key = "ssh-rsa AAAAB3Nza"
+			}
+
+			provisioner {
+				type   = "remote-exec"
+				inline = ["echo hello from %[1]s"]
+
+				connection {
+					user        = "root"
+					private_key = file("~/.ssh/id_rsa")
+					%[2]s
+				}
+			}
+		}
+	`, name, connectionExtra)
+}
@@ -0,0 +1,186 @@
+package nutanix
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-nutanix/client/era"
+	"github.com/terraform-providers/terraform-provider-nutanix/utils"
+)
+
+func resourceNutanixNDBServerVMSnapshot() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNutanixNDBServerVMSnapshotCreate,
+		ReadContext:   resourceNutanixNDBServerVMSnapshotRead,
+		DeleteContext: resourceNutanixNDBServerVMSnapshotDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(EraDBProvisionTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			"dbserver_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"expire_in_days": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+			},
+			"replicate_to_clusters": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			// computed
+			"snapshot_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"size_bytes": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"created_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceNutanixNDBServerVMSnapshotCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*Client).Era
+
+	req := &era.DBServerVMSnapshotInput{
+		Name: utils.StringPtr(d.Get("name").(string)),
+	}
+
+	if v, ok := d.GetOk("expire_in_days"); ok {
+		req.ExpireInDays = utils.IntPtr(v.(int))
+	}
+
+	if v, ok := d.GetOk("replicate_to_clusters"); ok {
+		clusters := v.([]interface{})
+		ids := make([]*string, 0, len(clusters))
+		for _, c := range clusters {
+			ids = append(ids, utils.StringPtr(c.(string)))
+		}
+		req.ReplicateToClusterIDs = ids
+	}
+
+	dbserverID := d.Get("dbserver_id").(string)
+
+	resp, err := conn.Service.SnapshotDBServerVM(ctx, req, dbserverID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(resp.Entityid)
+
+	opID := resp.Operationid
+	if opID == "" {
+		return diag.Errorf("error: operation ID is an empty string")
+	}
+	opReq := era.GetOperationRequest{
+		OperationID: opID,
+	}
+
+	log.Printf("polling for operation with id: %s\n", opID)
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"PENDING"},
+		Target:  []string{"COMPLETED", "FAILED"},
+		Refresh: eraRefresh(ctx, conn, opReq),
+		Timeout: d.Timeout(schema.TimeoutCreate),
+		Delay:   eraDelay,
+	}
+
+	if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+		return diag.Errorf("error waiting for db Server VM snapshot (%s) to create: %s", resp.Entityid, errWaitTask)
+	}
+
+	log.Printf("NDB database Server VM snapshot with %s id is created successfully", d.Id())
+	return resourceNutanixNDBServerVMSnapshotRead(ctx, d, meta)
+}
+
+func resourceNutanixNDBServerVMSnapshotRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*Client).Era
+
+	resp, err := conn.Service.ReadDBServerVMSnapshot(ctx, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("name", resp.Name); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("dbserver_id", resp.DbserverID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("snapshot_id", resp.SnapshotID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("size_bytes", resp.SizeBytes); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("created_at", resp.CreatedAt); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceNutanixNDBServerVMSnapshotDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*Client).Era
+
+	res, err := conn.Service.DeleteDBServerVMSnapshot(ctx, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("Operation to delete dbserver vm snapshot with id %s has started, operation id: %s", d.Id(), res.Operationid)
+	opID := res.Operationid
+	if opID == "" {
+		return diag.Errorf("error: operation ID is an empty string")
+	}
+	opReq := era.GetOperationRequest{
+		OperationID: opID,
+	}
+
+	log.Printf("polling for operation with id: %s\n", opID)
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"PENDING"},
+		Target:  []string{"COMPLETED", "FAILED"},
+		Refresh: eraRefresh(ctx, conn, opReq),
+		Timeout: d.Timeout(schema.TimeoutCreate),
+		Delay:   eraDelay,
+	}
+
+	if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+		return diag.Errorf("error waiting for db server VM snapshot (%s) to delete: %s", res.Entityid, errWaitTask)
+	}
+
+	log.Printf("NDB database Server VM snapshot with %s id is deleted successfully", d.Id())
+	return nil
+}
@@ -0,0 +1,40 @@
+package nutanix
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const resourceNameServerVMSnapshot = "nutanix_ndb_server_vm_snapshot.acctest-managed"
+
+func TestAccEraServerVMSnapshot_basic(t *testing.T) {
+	name := "test-vmsnapshot-tf"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccEraPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEraServerVMSnapshot(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceNameServerVMSnapshot, "name", name),
+					resource.TestCheckResourceAttrSet(resourceNameServerVMSnapshot, "id"),
+					resource.TestCheckResourceAttrSet(resourceNameServerVMSnapshot, "snapshot_id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccEraServerVMSnapshot(name string) string {
+	return fmt.Sprintf(`
+		%s
+
+		resource "nutanix_ndb_server_vm_snapshot" "acctest-managed" {
+			dbserver_id    = nutanix_ndb_server_vm.acctest-managed.id
+			name           = "%[2]s"
+			expire_in_days = 7
+		}
+	`, testAccEraServerVMConfig(name), name)
+}
@@ -0,0 +1,92 @@
+package nutanix
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const resourceNameServerVM = "nutanix_ndb_server_vm.acctest-managed"
+
+func TestAccEraServerVM_import(t *testing.T) {
+	name := "test-servervm-tf"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccEraPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEraServerVMConfig(name),
+			},
+			{
+				ResourceName:      resourceNameServerVM,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"vm_password", "credentials.0.password", "provisioner", "patch_now",
+				},
+			},
+		},
+	})
+}
+
+func testAccEraServerVMConfig(name string) string {
+	return fmt.Sprintf(`
+		resource "nutanix_ndb_server_vm" "acctest-managed" {
+			database_type                = "postgres_database"
+			software_profile_id          = "%[1]s-swprofile"
+			software_profile_version_id  = "%[1]s-swversion"
+			network_profile_id           = "%[1]s-netprofile"
+			compute_profile_id           = "%[1]s-computeprofile"
+			nx_cluster_id                = "%[1]s-cluster"
+			vm_password                  = "test-password"
+
+			postgres_database {
+				vm_name           = "%[1]s"
+				client_public_key = "ssh-rsa AAAAB3Nza"
+			}
+		}
+	`, name)
+}
+
+func TestAccEraServerVM_patchNow(t *testing.T) {
+	name := "test-patchnow-tf"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccEraPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEraServerVMPatchNowConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceNameServerVM, "patch_now.0.task_type", "OS_PATCHING"),
+					resource.TestCheckResourceAttrSet(resourceNameServerVM, "last_patch_operation_id"),
+					resource.TestCheckResourceAttrSet(resourceNameServerVM, "last_patched_at"),
+				),
+			},
+		},
+	})
+}
+
+func testAccEraServerVMPatchNowConfig(name string) string {
+	return fmt.Sprintf(`
+		resource "nutanix_ndb_server_vm" "acctest-managed" {
+			database_type                = "postgres_database"
+			software_profile_id          = "%[1]s-swprofile"
+			software_profile_version_id  = "%[1]s-swversion"
+			network_profile_id           = "%[1]s-netprofile"
+			compute_profile_id           = "%[1]s-computeprofile"
+			nx_cluster_id                = "%[1]s-cluster"
+			vm_password                  = "test-password"
+
+			postgres_database {
+				vm_name           = "%[1]s"
+				client_public_key = "ssh-rsa AAAAB3Nza"
+			}
+
+			patch_now {
+				task_type            = "OS_PATCHING"
+				wait_for_completion  = true
+			}
+		}
+	`, name)
+}